@@ -65,6 +65,10 @@ type App struct {
 	Logo Logo
 	// Strict use strict mode. short opt must be begin '-', long opt must be begin '--'
 	Strict bool
+	// ExitErrHandler handles errors flushed by Exit. Defaults to printing
+	// the error and calling os.Exit with its code, but can be overridden
+	// (eg. in tests) to capture errors without exiting the process.
+	ExitErrHandler func(app *App, err error)
 	// vars you can add some vars map for render help info
 	// vars map[string]string
 	// command names. key is name, value is name string length
@@ -78,6 +82,10 @@ type App struct {
 	commands map[string]*Command
 	// all commands by module
 	moduleCommands map[string]map[string]*Command
+	// category names in declaration order, set via AddCategory
+	categoryOrder []string
+	// category name => short description, set via AddCategory
+	categoryDescs map[string]string
 	// current command name
 	commandName string
 	// the max length for added command names. default set 12.
@@ -86,6 +94,13 @@ type App struct {
 	defaultCommand string
 	// clean os.args, not contains bin-name and command-name
 	cleanArgs []string
+	// ConfigDecoders maps a config file format (eg. "json") to the
+	// decoder LoadConfig should use for it. Register more via
+	// app.ConfigDecoders["yaml"] = myYAMLDecoder{} to support other formats.
+	ConfigDecoders map[string]ConfigDecoder
+	// values loaded via LoadConfig, used as an option fallback source
+	// below env vars and above Opt.Default.
+	configValues map[string]string
 }
 
 // NewApp create new app instance.
@@ -106,6 +121,8 @@ func NewApp(fn ...func(a *App)) *App {
 		commands:       make(map[string]*Command),
 		moduleCommands: make(map[string]map[string]*Command),
 		nameMaxLength:  12,
+		ExitErrHandler: defaultExitErrHandler,
+		ConfigDecoders: map[string]ConfigDecoder{"json": JSONDecoder{}},
 	}
 
 	if len(fn) > 0 {
@@ -131,6 +148,11 @@ func (app *App) Initialize() {
 
 	// init some help tpl vars
 	app.AddVars(app.helpVars())
+	app.AddVars(map[string]string{
+		// option resolution order, for use in option help text eg.
+		// "{$optPrecedence}: --token, $APP_TOKEN, --config file, default"
+		"optPrecedence": optPrecedenceText,
+	})
 
 	// parse GlobalOpts
 	// parseGlobalOpts()
@@ -138,6 +160,10 @@ func (app *App) Initialize() {
 	// add default error handler.
 	app.SimpleHooks.Add(EvtError, defaultErrHandler)
 
+	// register built-in commands
+	app.addCompletionCommand()
+	app.addHelpCommand()
+
 	app.fireEvent(EvtInit, nil)
 }
 
@@ -230,8 +256,9 @@ func (app *App) AddCommand(c *Command) *Command {
 	app.AddAliases(c.Name, c.Aliases)
 	Logf(VerbDebug, "[App.AddCommand] add a new CLI command: %s", c.Name)
 
-	// init command
-	c.app = app
+	// init command, propagating app to any subcommands registered
+	// before this command was added to the app.
+	c.setApp(app)
 	c.initialize()
 	return c
 }
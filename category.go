@@ -0,0 +1,96 @@
+package gcli
+
+import (
+	"sort"
+)
+
+// UncategorizedCategory is the fallback bucket for commands that don't
+// set a Category.
+const UncategorizedCategory = "Uncategorized"
+
+/*************************************************************
+ * category grouped help
+ *************************************************************/
+
+// AddCategory registers a command category with a short description.
+// Categories are rendered in help output in the order they were added
+// via AddCategory, whether it is called before or after AddCommand.
+func (app *App) AddCategory(name, desc string) {
+	if app.categoryDescs == nil {
+		app.categoryDescs = make(map[string]string)
+	}
+
+	if _, ok := app.categoryDescs[name]; !ok {
+		app.categoryOrder = append(app.categoryOrder, name)
+	}
+	app.categoryDescs[name] = desc
+}
+
+// CategoryDesc returns the description registered via AddCategory for name.
+func (app *App) CategoryDesc(name string) string {
+	return app.categoryDescs[name]
+}
+
+// CommandsByCategory groups all registered commands by their Category,
+// falling back to UncategorizedCategory for commands that don't set one.
+func (app *App) CommandsByCategory() map[string][]*Command {
+	grouped := make(map[string][]*Command)
+
+	for _, c := range app.commands {
+		cat := c.Category
+		if cat == "" {
+			cat = UncategorizedCategory
+		}
+		grouped[cat] = append(grouped[cat], c)
+	}
+
+	for cat, cmds := range grouped {
+		sort.Slice(cmds, func(i, j int) bool { return cmds[i].Name < cmds[j].Name })
+		grouped[cat] = cmds
+	}
+
+	return grouped
+}
+
+// categoryNames returns the category names in the order they should be
+// rendered: the order declared via AddCategory first, then any
+// categories discovered on commands but never registered (sorted), and
+// finally UncategorizedCategory - always last, if non-empty.
+func (app *App) categoryNames(grouped map[string][]*Command) []string {
+	seen := make(map[string]bool, len(app.categoryOrder))
+	names := make([]string, 0, len(grouped))
+
+	for _, name := range app.categoryOrder {
+		if _, ok := grouped[name]; ok && !seen[name] {
+			names = append(names, name)
+			seen[name] = true
+		}
+	}
+
+	var extra []string
+	for name := range grouped {
+		if name == UncategorizedCategory || seen[name] {
+			continue
+		}
+		extra = append(extra, name)
+	}
+	sort.Strings(extra)
+	names = append(names, extra...)
+
+	if _, ok := grouped[UncategorizedCategory]; ok {
+		names = append(names, UncategorizedCategory)
+	}
+	return names
+}
+
+// HasCategories reports whether any registered command has set a Category.
+// Help rendering should prefer category grouping over the flat
+// moduleCommands layout only when this is true.
+func (app *App) HasCategories() bool {
+	for _, c := range app.commands {
+		if c.Category != "" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,62 @@
+package gcli
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestApp_CommandsByCategory(t *testing.T) {
+	app := newTestApp()
+	app.AddCategory("Data", "data management commands")
+	app.AddCategory("Network", "network commands")
+
+	app.AddCommand(NewCommand("push", "push data", func(c *Command) { c.Category = "Data" }))
+	app.AddCommand(NewCommand("pull", "pull data", func(c *Command) { c.Category = "Data" }))
+	app.AddCommand(NewCommand("ping", "ping a host", func(c *Command) { c.Category = "Network" }))
+	app.AddCommand(NewCommand("misc", "no category", nil))
+
+	grouped := app.CommandsByCategory()
+	if len(grouped["Data"]) != 2 {
+		t.Fatalf("expected 2 commands in Data category, got %d", len(grouped["Data"]))
+	}
+	if len(grouped[UncategorizedCategory]) != 3 { // misc + the built-in completion and help commands
+		t.Fatalf("expected 3 uncategorized commands, got %d", len(grouped[UncategorizedCategory]))
+	}
+
+	names := app.categoryNames(grouped)
+	want := []string{"Data", "Network", UncategorizedCategory}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("category order = %v, want %v", names, want)
+	}
+}
+
+func TestApp_RenderHelp_categoryTakesPrecedence(t *testing.T) {
+	app := newTestApp()
+	app.AddCategory("Data", "data management commands")
+	app.AddCommand(NewCommand("db:push", "push data", func(c *Command) { c.Category = "Data" }))
+
+	var buf bytes.Buffer
+	app.RenderHelp(&buf)
+
+	out := buf.String()
+	if !strings.Contains(out, "Data:") {
+		t.Fatalf("expected help output to use the Category heading, got:\n%s", out)
+	}
+	if strings.Contains(out, "db:\n") {
+		t.Errorf("module grouping should not be used once a command sets Category, got:\n%s", out)
+	}
+}
+
+func TestApp_HasCategories(t *testing.T) {
+	app := newTestApp()
+	if app.HasCategories() {
+		t.Error("expected no categories on a fresh app")
+	}
+
+	app.AddCommand(NewCommand("build", "build it", func(c *Command) { c.Category = "Dev" }))
+	if !app.HasCategories() {
+		t.Error("expected HasCategories to be true once a command sets Category")
+	}
+}
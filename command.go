@@ -0,0 +1,90 @@
+package gcli
+
+import (
+	"strings"
+)
+
+/*************************************************************
+ * CLI command
+ *************************************************************/
+
+// CmdFunc definition for command running
+type CmdFunc func(c *Command, args []string) error
+
+// CompletionFn is a per-command dynamic completion provider.
+// It receives the already-parsed argv for the current command (not
+// including the command name itself) and returns candidate values for
+// the next argument, eg. file paths or remote resource names.
+type CompletionFn func(args []string) []string
+
+// Command a CLI command definition
+type Command struct {
+	SimpleHooks // allow hooks: "before", "after", "error" for this command and its subcommands
+
+	// Name is the command name. supports `module:name` to group
+	// commands under a module prefix.
+	Name string
+	// UseFor is the short description, shown in the commands list.
+	UseFor string
+	// Aliases alias names for the command
+	Aliases []string
+	// Module the module name, parsed from Name on AddCommand.
+	Module string
+	// Category groups the command under a named heading in help output.
+	// Commands without a Category fall back to the "Uncategorized" bucket.
+	Category string
+	// Disabled mark the command as disabled, it will be skipped on AddCommand.
+	Disabled bool
+	// Fn the command handler func
+	Fn CmdFunc
+	// CompletionFn provides dynamic shell-completion candidates for this
+	// command's arguments. Optional - leave nil for commands that only
+	// want flag-based completion.
+	CompletionFn CompletionFn
+	// Subcommands holds the child commands registered via AddSubCommand,
+	// keyed by their own Name. Lets callers build real nesting
+	// (`app my-cmd sub-cmd --flag`) instead of the flat `module:name`
+	// convention.
+	Subcommands map[string]*Command
+
+	// internal use. point to the parent app
+	app *App
+	// internal use. point to the parent command, nil for top level commands
+	parent *Command
+	// internal use. options registered via AddOpt, keyed by Opt.Name
+	opts map[string]*Opt
+}
+
+// NewCommand create a new command instance
+func NewCommand(name, useFor string, config func(c *Command)) *Command {
+	c := &Command{
+		Name:   strings.TrimSpace(name),
+		UseFor: useFor,
+	}
+
+	if config != nil {
+		config(c)
+	}
+	return c
+}
+
+// IsDisabled check if the command has been disabled
+func (c *Command) IsDisabled() bool {
+	return c.Disabled
+}
+
+// AddAliases add alias names for a command name
+func (app *App) AddAliases(name string, aliases []string) {
+	if app.aliases == nil {
+		app.aliases = make(map[string]string)
+	}
+
+	for _, alias := range aliases {
+		app.aliases[alias] = name
+	}
+}
+
+// initialize the command after it has been added to an App
+func (c *Command) initialize() {
+	// nothing to prepare yet, reserved for future setup (flags binding etc.)
+}
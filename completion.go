@@ -0,0 +1,188 @@
+package gcli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// GenBashCompleteFlag is the hidden flag used by the bash/zsh scripts to
+// ask the binary itself for completion candidates instead of hard-coding
+// them in the shell script.
+const GenBashCompleteFlag = "--generate-bash-completion"
+
+// CompletionCommand is the name of the built-in `completion` command.
+const CompletionCommand = "completion"
+
+// bashCompletionTpl hooks `complete -F` for the app to the running binary.
+// modelled on urfave/cli's autocomplete/bash_autocomplete helper.
+const bashCompletionTpl = `#! /bin/bash
+
+_gcli_%s() {
+	local cur opts
+	COMPREPLY=()
+	cur="${COMP_WORDS[COMP_CWORD]}"
+
+	opts=$(%s %s "${COMP_WORDS[@]:1:$COMP_CWORD-1}")
+
+	COMPREPLY=( $(compgen -W "${opts}" -- "${cur}") )
+	return 0
+}
+
+complete -F _gcli_%s %s
+`
+
+// zshCompletionTpl uses compdef + a generator function that shells back
+// into the binary's --generate-bash-completion flag for every position,
+// same as the bash variant, so it descends into subcommands too.
+const zshCompletionTpl = `#compdef %s
+
+_gcli_%s() {
+	local -a candidates
+	candidates=("${(@f)$(%s %s ${words[@]:1:#words[@]-2})}")
+
+	_describe 'command' candidates
+}
+
+compdef _gcli_%s %s
+`
+
+// GenCompletion writes a bash or zsh completion script for the app to w.
+// shell must be "bash" or "zsh".
+func (app *App) GenCompletion(shell string, w io.Writer) error {
+	switch shell {
+	case "bash":
+		_, err := fmt.Fprintf(w, bashCompletionTpl, app.binName(), app.binName(), GenBashCompleteFlag, app.binName(), app.binName())
+		return err
+	case "zsh":
+		_, err := fmt.Fprintf(w, zshCompletionTpl, app.binName(), app.binName(), app.binName(), GenBashCompleteFlag, app.binName(), app.binName())
+		return err
+	default:
+		return fmt.Errorf("gcli: unsupported shell %q for completion, want bash or zsh", shell)
+	}
+}
+
+// binName returns the app name sanitized into a valid shell identifier
+// and command token, eg. "My CLI App" -> "My_CLI_App". App.Name is a
+// free-form display string (NewApp defaults it to "My CLI App"), so it
+// can't be plugged into a bash function name or invoked verbatim.
+func (app *App) binName() string {
+	var b strings.Builder
+	for _, r := range app.Name {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('_')
+		}
+	}
+
+	name := b.String()
+	if name == "" {
+		return "app"
+	}
+	if unicode.IsDigit(rune(name[0])) {
+		name = "_" + name
+	}
+	return name
+}
+
+// genCompletionCandidates resolves the completion candidates for the
+// current partial command line. args is the clean argv (bin name and
+// the `--generate-bash-completion` flag already stripped).
+func (app *App) genCompletionCandidates(args []string) []string {
+	// no partial command yet: suggest top level command and alias names
+	if len(args) == 0 {
+		return app.topLevelNames()
+	}
+
+	name := args[0]
+	if real, ok := app.aliases[name]; ok {
+		name = real
+	}
+
+	c, ok := app.commands[name]
+	if !ok {
+		return app.topLevelNames()
+	}
+
+	// descend into subcommands for the rest of the partial command line
+	c, rest := c.resolve(args[1:])
+	if len(rest) > 0 && len(c.Subcommands) > 0 {
+		if _, ok := c.Subcommands[rest[0]]; !ok {
+			return append(c.subcommandNames(), c.flagNames()...)
+		}
+	}
+
+	if c.CompletionFn != nil {
+		return append(c.CompletionFn(rest), c.flagNames()...)
+	}
+	if len(c.Subcommands) > 0 {
+		return append(c.subcommandNames(), c.flagNames()...)
+	}
+	return c.flagNames()
+}
+
+// subcommandNames lists the names of a command's direct subcommands.
+func (c *Command) subcommandNames() []string {
+	names := make([]string, 0, len(c.Subcommands))
+	for name := range c.Subcommands {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// topLevelNames lists the registered command and alias names.
+func (app *App) topLevelNames() []string {
+	names := make([]string, 0, len(app.commands)+len(app.aliases))
+	for name := range app.commands {
+		names = append(names, name)
+	}
+	for alias := range app.aliases {
+		names = append(names, alias)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// HandleCompletion checks whether args request shell completion (the
+// hidden --generate-bash-completion flag) and, if so, prints one
+// candidate per line to stdout and returns true. Callers (eg. App.Run)
+// should skip normal dispatch when this returns true.
+func (app *App) HandleCompletion(args []string) bool {
+	pos := -1
+	for i, a := range args {
+		if a == GenBashCompleteFlag {
+			pos = i
+			break
+		}
+	}
+	if pos == -1 {
+		return false
+	}
+
+	for _, candidate := range app.genCompletionCandidates(args[pos+1:]) {
+		fmt.Fprintln(os.Stdout, candidate)
+	}
+	return true
+}
+
+// addCompletionCommand registers the built-in `completion` command that
+// prints the bash/zsh script for the current app to stdout.
+func (app *App) addCompletionCommand() {
+	app.AddCommand(NewCommand(CompletionCommand, "Generate shell completion scripts (bash, zsh)", func(c *Command) {
+		c.Fn = func(c *Command, args []string) error {
+			shell := "bash"
+			if len(args) > 0 {
+				shell = args[0]
+			}
+
+			return app.GenCompletion(shell, os.Stdout)
+		}
+	}))
+}
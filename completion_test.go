@@ -0,0 +1,156 @@
+package gcli
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newTestApp() *App {
+	return NewApp(func(a *App) {
+		a.Name = "myapp"
+	})
+}
+
+func readGolden(t *testing.T, name string) string {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("read golden file %s: %v", name, err)
+	}
+	return string(data)
+}
+
+func TestApp_GenCompletion_bash(t *testing.T) {
+	app := newTestApp()
+
+	var buf bytes.Buffer
+	if err := app.GenCompletion("bash", &buf); err != nil {
+		t.Fatalf("GenCompletion(bash) error: %v", err)
+	}
+
+	want := readGolden(t, "completion_bash.golden")
+	if buf.String() != want {
+		t.Errorf("bash completion script mismatch:\ngot:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestApp_GenCompletion_zsh(t *testing.T) {
+	app := newTestApp()
+
+	var buf bytes.Buffer
+	if err := app.GenCompletion("zsh", &buf); err != nil {
+		t.Fatalf("GenCompletion(zsh) error: %v", err)
+	}
+
+	want := readGolden(t, "completion_zsh.golden")
+	if buf.String() != want {
+		t.Errorf("zsh completion script mismatch:\ngot:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestApp_GenCompletion_sanitizesAppName(t *testing.T) {
+	app := NewApp() // the out-of-the-box default: Name == "My CLI App"
+
+	var buf bytes.Buffer
+	if err := app.GenCompletion("bash", &buf); err != nil {
+		t.Fatalf("GenCompletion(bash) error: %v", err)
+	}
+
+	out := buf.String()
+	if strings.Contains(out, "My CLI App") {
+		t.Errorf("expected the raw app name to be sanitized out of the script, got:\n%s", out)
+	}
+	if !strings.Contains(out, "_gcli_My_CLI_App") {
+		t.Errorf("expected a sanitized, space-free identifier, got:\n%s", out)
+	}
+}
+
+func TestApp_binName(t *testing.T) {
+	app := newTestApp()
+	app.Name = "My CLI App"
+
+	if got := app.binName(); got != "My_CLI_App" {
+		t.Errorf("binName() = %q, want %q", got, "My_CLI_App")
+	}
+}
+
+func TestApp_GenCompletion_unsupportedShell(t *testing.T) {
+	app := newTestApp()
+
+	if err := app.GenCompletion("fish", &bytes.Buffer{}); err == nil {
+		t.Error("expected an error for an unsupported shell, got nil")
+	}
+}
+
+func TestApp_HandleCompletion(t *testing.T) {
+	app := newTestApp()
+	app.AddCommand(NewCommand("build", "build the project", nil))
+
+	if app.HandleCompletion([]string{"myapp", "build"}) {
+		t.Error("HandleCompletion should be false without the generate flag")
+	}
+
+	if !app.HandleCompletion([]string{"myapp", GenBashCompleteFlag}) {
+		t.Error("HandleCompletion should be true when the generate flag is present")
+	}
+}
+
+// captureStdout runs fn with os.Stdout redirected, returning everything
+// written to it.
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("os.Pipe: %v", err)
+	}
+
+	orig := os.Stdout
+	os.Stdout = w
+	fn()
+	w.Close()
+	os.Stdout = orig
+
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("read captured stdout: %v", err)
+	}
+	return string(out)
+}
+
+func TestApp_HandleCompletion_descendsIntoSubcommands(t *testing.T) {
+	app := newTestApp()
+	top := app.AddCommand(NewCommand("my-cmd", "a top command", nil))
+	top.AddSubCommand(NewCommand("sub", "a sub command", nil))
+
+	// the binary name, the hidden flag at a fixed position, then the
+	// partial command, as the generated bash script invokes it. The
+	// script passes only the words before the one being typed; the
+	// trailing "" here stands for an empty word being typed.
+	out := captureStdout(t, func() {
+		app.HandleCompletion([]string{"myapp", GenBashCompleteFlag, "my-cmd", ""})
+	})
+
+	if out != "sub\n" {
+		t.Errorf("expected completion to descend into subcommands and suggest %q, got %q", "sub\n", out)
+	}
+}
+
+func TestApp_HandleCompletion_includesCommandFlags(t *testing.T) {
+	app := newTestApp()
+	build := app.AddCommand(NewCommand("build", "build the project", nil))
+	build.AddOpt(&Opt{Name: "token"})
+
+	out := captureStdout(t, func() {
+		app.HandleCompletion([]string{"myapp", GenBashCompleteFlag, "build", ""})
+	})
+
+	if !strings.Contains(out, "--token\n") {
+		t.Errorf("expected the command's registered options to be offered as flag candidates, got %q", out)
+	}
+}
@@ -0,0 +1,86 @@
+package gcli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+/*************************************************************
+ * config file fallback
+ *************************************************************/
+
+// ConfigFlag is the global flag that, when set, loads its value as a
+// config file before EvtBefore fires, populating option defaults.
+const ConfigFlag = "config"
+
+// ConfigDecoder decodes a config file's content into a map of raw
+// values. Implement it to add support for a format beyond the built-in
+// JSONDecoder, eg. YAML or TOML, and register it with App.ConfigDecoders.
+type ConfigDecoder interface {
+	Decode(r io.Reader, v any) error
+}
+
+// JSONDecoder decodes JSON config files using encoding/json.
+type JSONDecoder struct{}
+
+// Decode implements ConfigDecoder
+func (JSONDecoder) Decode(r io.Reader, v any) error {
+	return json.NewDecoder(r).Decode(v)
+}
+
+// LoadConfig reads path, decodes it with the decoder registered for
+// format, and stores each top-level value, formatted as a string with
+// %v, as a third fallback source for Command.Resolve, below env vars and
+// above Opt.Default. Nested objects are not flattened into separate keys.
+func (app *App) LoadConfig(path string, format string) error {
+	decoder, ok := app.ConfigDecoders[format]
+	if !ok {
+		return fmt.Errorf("gcli: no ConfigDecoder registered for format %q", format)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	raw := make(map[string]interface{})
+	if err := decoder.Decode(f, &raw); err != nil {
+		return fmt.Errorf("gcli: decode config %s: %w", path, err)
+	}
+
+	if app.configValues == nil {
+		app.configValues = make(map[string]string)
+	}
+	for k, v := range raw {
+		app.configValues[k] = fmt.Sprintf("%v", v)
+	}
+	return nil
+}
+
+// WatchConfigFlag looks for a global "--config=path"/"--config path" flag
+// in args and, if present, loads it via LoadConfig (format guessed from
+// the file extension) so its values are available as option defaults
+// before EvtBefore fires. Run should call this ahead of dispatch.
+func (app *App) WatchConfigFlag(args []string) error {
+	for i, a := range args {
+		var path string
+
+		switch {
+		case strings.HasPrefix(a, "--"+ConfigFlag+"="):
+			path = strings.TrimPrefix(a, "--"+ConfigFlag+"=")
+		case a == "--"+ConfigFlag && i+1 < len(args):
+			path = args[i+1]
+		default:
+			continue
+		}
+
+		format := strings.TrimPrefix(filepath.Ext(path), ".")
+		return app.LoadConfig(path, format)
+	}
+	return nil
+}
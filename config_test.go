@@ -0,0 +1,49 @@
+package gcli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestApp_LoadConfig_json(t *testing.T) {
+	app := newTestApp()
+
+	path := filepath.Join(t.TempDir(), "app.json")
+	if err := os.WriteFile(path, []byte(`{"token": "from-config"}`), 0o644); err != nil {
+		t.Fatalf("write temp config: %v", err)
+	}
+
+	if err := app.LoadConfig(path, "json"); err != nil {
+		t.Fatalf("LoadConfig error: %v", err)
+	}
+
+	if app.configValues["token"] != "from-config" {
+		t.Errorf("configValues[token] = %q, want %q", app.configValues["token"], "from-config")
+	}
+}
+
+func TestApp_LoadConfig_unknownFormat(t *testing.T) {
+	app := newTestApp()
+
+	if err := app.LoadConfig("whatever.yaml", "yaml"); err == nil {
+		t.Error("expected an error for an unregistered config format")
+	}
+}
+
+func TestApp_WatchConfigFlag(t *testing.T) {
+	app := newTestApp()
+
+	path := filepath.Join(t.TempDir(), "app.json")
+	if err := os.WriteFile(path, []byte(`{"token": "watched"}`), 0o644); err != nil {
+		t.Fatalf("write temp config: %v", err)
+	}
+
+	if err := app.WatchConfigFlag([]string{"myapp", "--config=" + path}); err != nil {
+		t.Fatalf("WatchConfigFlag error: %v", err)
+	}
+
+	if app.configValues["token"] != "watched" {
+		t.Errorf("configValues[token] = %q, want %q", app.configValues["token"], "watched")
+	}
+}
@@ -0,0 +1,93 @@
+package gcli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+/*************************************************************
+ * exit errors
+ *************************************************************/
+
+// ExitError is an error carrying the process exit code it should cause.
+// Command Fn implementations can return one to choose a specific exit
+// code instead of always failing with ERR.
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+// NewExitError creates an ExitError from a plain message.
+func NewExitError(code int, msg string) *ExitError {
+	return &ExitError{Code: code, Err: errors.New(msg)}
+}
+
+// Error implements the error interface
+func (e *ExitError) Error() string {
+	if e.Err == nil {
+		return ""
+	}
+	return e.Err.Error()
+}
+
+// ExitCode returns the exit code the process should exit with.
+func (e *ExitError) ExitCode() int {
+	return e.Code
+}
+
+// MultiError aggregates several errors, eg. app.errors collected via
+// App.AddError, into a single error for the ExitErrHandler.
+type MultiError struct {
+	Errors []error
+}
+
+// NewMultiError builds a MultiError from the given errors.
+func NewMultiError(errs []error) *MultiError {
+	return &MultiError{Errors: errs}
+}
+
+// Error implements the error interface, joining every message with "; ".
+func (e *MultiError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// defaultExitErrHandler prints err and exits the process with its code.
+// It's the default value of App.ExitErrHandler - override it (eg. in
+// tests) to capture errors without calling os.Exit.
+func defaultExitErrHandler(app *App, err error) {
+	if err == nil {
+		return
+	}
+
+	code := ERR
+	if ee, ok := err.(*ExitError); ok {
+		code = ee.Code
+	}
+
+	fmt.Fprintln(os.Stderr, "ERROR:", err)
+	os.Exit(code)
+}
+
+// Exit flushes any errors accumulated via AddError through ExitErrHandler.
+// Call it at the end of Run so commands that merely record errors (rather
+// than returning them) still surface and exit with the right code.
+func (app *App) Exit() {
+	if len(app.errors) == 0 {
+		return
+	}
+
+	var err error
+	if len(app.errors) == 1 {
+		err = app.errors[0]
+	} else {
+		err = NewMultiError(app.errors)
+	}
+
+	app.ExitErrHandler(app, err)
+}
@@ -0,0 +1,69 @@
+package gcli
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestApp_Exit_customHandler(t *testing.T) {
+	app := newTestApp()
+
+	var gotErr error
+	var gotCode int
+	app.ExitErrHandler = func(a *App, err error) {
+		gotErr = err
+		if ee, ok := err.(*ExitError); ok {
+			gotCode = ee.Code
+		}
+	}
+
+	app.AddError(NewExitError(3, "boom"))
+	app.Exit()
+
+	if gotErr == nil || gotErr.Error() != "boom" {
+		t.Fatalf("expected ExitErrHandler to receive %q, got %v", "boom", gotErr)
+	}
+	if gotCode != 3 {
+		t.Errorf("expected exit code 3, got %d", gotCode)
+	}
+}
+
+func TestNewExitError_literalPercent(t *testing.T) {
+	msg := "rate limit: 50% exceeded"
+	e := NewExitError(1, msg)
+
+	if e.Error() != msg {
+		t.Errorf("NewExitError mangled a literal %%: got %q, want %q", e.Error(), msg)
+	}
+}
+
+func TestApp_Exit_multiError(t *testing.T) {
+	app := newTestApp()
+
+	var gotErr error
+	app.ExitErrHandler = func(a *App, err error) { gotErr = err }
+
+	app.AddError(errors.New("first"))
+	app.AddError(errors.New("second"))
+	app.Exit()
+
+	me, ok := gotErr.(*MultiError)
+	if !ok {
+		t.Fatalf("expected a *MultiError, got %T", gotErr)
+	}
+	if me.Error() != "first; second" {
+		t.Errorf("MultiError.Error() = %q", me.Error())
+	}
+}
+
+func TestApp_Exit_noErrors(t *testing.T) {
+	app := newTestApp()
+
+	called := false
+	app.ExitErrHandler = func(a *App, err error) { called = true }
+
+	app.Exit()
+	if called {
+		t.Error("ExitErrHandler should not be called when there are no errors")
+	}
+}
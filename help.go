@@ -0,0 +1,195 @@
+package gcli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// HelpFlags are the argv tokens that trigger help output for the
+// current command instead of running it.
+var HelpFlags = []string{"-h", "--help"}
+
+// hasHelpFlag reports whether args requests help for the current command.
+func hasHelpFlag(args []string) bool {
+	for _, a := range args {
+		for _, flag := range HelpFlags {
+			if a == flag {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Help writes this command's help text to w: its name, description,
+// registered Opts (with their env var / default fallbacks) and - when
+// it has any - a "Subcommands:" section so nested commands are
+// discoverable from `app my-cmd --help` without needing the full tree.
+func (c *Command) Help(w io.Writer) {
+	fmt.Fprintf(w, "Usage: %s\n\n", c.helpUsage())
+	if c.UseFor != "" {
+		fmt.Fprintln(w, c.UseFor)
+		fmt.Fprintln(w)
+	}
+
+	if len(c.opts) > 0 {
+		c.helpOpts(w)
+	}
+
+	if len(c.Subcommands) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w, "Subcommands:")
+	for _, name := range c.subcommandNames() {
+		sub := c.Subcommands[name]
+		fmt.Fprintf(w, "  %-*s  %s\n", c.subNameWidth(), name, sub.UseFor)
+	}
+}
+
+// helpOpts writes the command's Options section: one line per Opt with
+// its description, then its env var and default fallbacks (if any), and
+// a closing line documenting the resolution order (see Command.Resolve).
+func (c *Command) helpOpts(w io.Writer) {
+	fmt.Fprintln(w, "Options:")
+	for _, name := range c.optNames() {
+		opt := c.opts[name]
+		fmt.Fprintf(w, "  --%s\n", name)
+		if opt.Desc != "" {
+			fmt.Fprintf(w, "      %s\n", opt.Desc)
+		}
+		if len(opt.EnvVars) > 0 {
+			fmt.Fprintf(w, "      env: %s\n", strings.Join(opt.EnvVars, ", "))
+		}
+
+		def := opt.DefaultText
+		if def == "" {
+			def = opt.Default
+		}
+		if def != "" {
+			fmt.Fprintf(w, "      default: %s\n", def)
+		}
+	}
+	fmt.Fprintf(w, "  (resolution order: %s)\n\n", optPrecedenceText)
+}
+
+// helpUsage renders "app my-cmd sub-cmd" by walking up c.parent.
+func (c *Command) helpUsage() string {
+	names := []string{c.Name}
+	for p := c.parent; p != nil; p = p.parent {
+		names = append([]string{p.Name}, names...)
+	}
+
+	bin := "app"
+	if c.app != nil {
+		bin = c.app.Name
+	}
+	return bin + " " + strings.Join(names, " ")
+}
+
+// subNameWidth is the column width used to align Subcommands: descriptions.
+func (c *Command) subNameWidth() int {
+	width := 0
+	for name := range c.Subcommands {
+		if len(name) > width {
+			width = len(name)
+		}
+	}
+	return width
+}
+
+// ShowCommandHelp resolves path (eg. ["my-cmd", "sub-cmd"]) through the
+// app's commands and their Subcommands tree and writes its help to w.
+// An empty path renders the app-level help (RenderHelp).
+func (app *App) ShowCommandHelp(w io.Writer, path []string) error {
+	if len(path) == 0 {
+		app.RenderHelp(w)
+		return nil
+	}
+
+	name := path[0]
+	if real, ok := app.aliases[name]; ok {
+		name = real
+	}
+
+	c, ok := app.commands[name]
+	if !ok {
+		return fmt.Errorf("unknown command: %s", name)
+	}
+
+	c, _ = c.resolve(path[1:])
+	c.Help(w)
+	return nil
+}
+
+// RenderHelp writes the app-level commands listing to w. Commands are
+// grouped under their Category heading, in AddCategory declaration
+// order, whenever any command has set one; otherwise it falls back to
+// the flat moduleCommands grouping.
+func (app *App) RenderHelp(w io.Writer) {
+	fmt.Fprintf(w, "%s\n\n", app.Name)
+	if app.Description != "" {
+		fmt.Fprintf(w, "%s\n\n", app.Description)
+	}
+
+	if app.HasCategories() {
+		app.renderCategoryHelp(w)
+		return
+	}
+	app.renderModuleHelp(w)
+}
+
+// renderCategoryHelp lists commands under their Category heading.
+func (app *App) renderCategoryHelp(w io.Writer) {
+	grouped := app.CommandsByCategory()
+	for _, cat := range app.categoryNames(grouped) {
+		fmt.Fprintf(w, "%s:\n", cat)
+		for _, c := range grouped[cat] {
+			fmt.Fprintf(w, "  %-*s  %s\n", app.nameMaxLength, c.Name, c.UseFor)
+		}
+		fmt.Fprintln(w)
+	}
+}
+
+// renderModuleHelp lists commands grouped by their `module:name` prefix,
+// the pre-Category behavior, used by RenderHelp when no command sets one.
+func (app *App) renderModuleHelp(w io.Writer) {
+	modules := make([]string, 0, len(app.moduleCommands))
+	for mod := range app.moduleCommands {
+		modules = append(modules, mod)
+	}
+	sort.Strings(modules)
+
+	for _, mod := range modules {
+		heading := mod
+		if heading == "" {
+			heading = UncategorizedCategory
+		}
+		fmt.Fprintf(w, "%s:\n", heading)
+
+		names := make([]string, 0, len(app.moduleCommands[mod]))
+		for name := range app.moduleCommands[mod] {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			c := app.moduleCommands[mod][name]
+			fmt.Fprintf(w, "  %-*s  %s\n", app.nameMaxLength, c.Name, c.UseFor)
+		}
+		fmt.Fprintln(w)
+	}
+}
+
+// addHelpCommand registers the built-in `help` command: `app help` shows
+// the app-level listing, `app help my-cmd sub-cmd` shows that command's help.
+func (app *App) addHelpCommand() {
+	app.AddCommand(NewCommand(HelpCommand, "Show help for the app or a command", func(c *Command) {
+		c.Fn = func(c *Command, args []string) error {
+			return app.ShowCommandHelp(os.Stdout, args)
+		}
+	}))
+}
@@ -0,0 +1,113 @@
+package gcli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestCommand_Help_listsSubcommands(t *testing.T) {
+	app := newTestApp()
+	top := app.AddCommand(NewCommand("my-cmd", "a top command", nil))
+	top.AddSubCommand(NewCommand("sub", "a sub command", nil))
+
+	var buf bytes.Buffer
+	top.Help(&buf)
+
+	out := buf.String()
+	if !strings.Contains(out, "Subcommands:") {
+		t.Fatalf("expected a Subcommands section, got:\n%s", out)
+	}
+	if !strings.Contains(out, "sub") || !strings.Contains(out, "a sub command") {
+		t.Errorf("expected the subcommand and its description listed, got:\n%s", out)
+	}
+}
+
+func TestApp_Dispatch_helpFlag(t *testing.T) {
+	app := newTestApp()
+
+	ran := false
+	top := app.AddCommand(NewCommand("my-cmd", "a top command", func(c *Command) {
+		c.Fn = func(c *Command, args []string) error { ran = true; return nil }
+	}))
+	top.AddSubCommand(NewCommand("sub", "a sub command", nil))
+
+	out := captureStdout(t, func() {
+		if err := app.Dispatch("my-cmd", []string{"--help"}); err != nil {
+			t.Fatalf("Dispatch error: %v", err)
+		}
+	})
+
+	if ran {
+		t.Error("--help should short-circuit the command's Fn")
+	}
+	if !strings.Contains(out, "Subcommands:") || !strings.Contains(out, "sub") {
+		t.Errorf("expected `my-cmd --help` to list its subcommands, got:\n%s", out)
+	}
+}
+
+func TestCommand_Help_listsOpts(t *testing.T) {
+	app := newTestApp()
+	deploy := app.AddCommand(NewCommand("deploy", "deploy the app", nil))
+	deploy.AddOpt(&Opt{
+		Name:    "token",
+		Desc:    "the deploy token",
+		EnvVars: []string{"APP_TOKEN"},
+		Default: "anon",
+	})
+
+	var buf bytes.Buffer
+	deploy.Help(&buf)
+
+	out := buf.String()
+	for _, want := range []string{"Options:", "--token", "the deploy token", "env: APP_TOKEN", "default: anon", optPrecedenceText} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected help output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestApp_Dispatch_helpFlag_afterSubcommand(t *testing.T) {
+	app := newTestApp()
+
+	subRan := false
+	top := app.AddCommand(NewCommand("my-cmd", "a top command", nil))
+	top.AddSubCommand(NewCommand("sub", "a nested sub command", func(c *Command) {
+		c.Fn = func(c *Command, args []string) error { subRan = true; return nil }
+	}))
+
+	out := captureStdout(t, func() {
+		if err := app.Dispatch("my-cmd", []string{"sub", "--help"}); err != nil {
+			t.Fatalf("Dispatch error: %v", err)
+		}
+	})
+
+	if subRan {
+		t.Error("--help should short-circuit sub's Fn")
+	}
+	if !strings.Contains(out, "my-cmd sub") {
+		t.Errorf("expected `my-cmd sub --help` to show sub's own usage, got:\n%s", out)
+	}
+	if strings.Contains(out, "Subcommands:") {
+		t.Errorf("expected sub's help (no subcommands of its own), not my-cmd's, got:\n%s", out)
+	}
+}
+
+func TestApp_ShowCommandHelp_nestedPath(t *testing.T) {
+	app := newTestApp()
+	top := app.AddCommand(NewCommand("my-cmd", "a top command", nil))
+	top.AddSubCommand(NewCommand("sub", "a nested sub command", nil))
+
+	var buf bytes.Buffer
+	if err := app.ShowCommandHelp(&buf, []string{"my-cmd", "sub"}); err != nil {
+		t.Fatalf("ShowCommandHelp error: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "my-cmd sub") {
+		t.Errorf("expected usage to include the full chain, got:\n%s", out)
+	}
+	if !strings.Contains(out, "a nested sub command") {
+		t.Errorf("expected the leaf command's own description, got:\n%s", out)
+	}
+}
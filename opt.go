@@ -0,0 +1,95 @@
+package gcli
+
+import (
+	"os"
+	"sort"
+)
+
+/*************************************************************
+ * option env/config fallback
+ *************************************************************/
+
+// optPrecedenceText documents the option resolution order shown both in
+// the app's HelpVars (see App.Initialize) and in Command.Help's Options
+// section.
+const optPrecedenceText = "CLI flag > env var > config file > default"
+
+// Opt describes a command option (flag) including the sources it falls
+// back to when no CLI value was given, in order: CLI arg -> first
+// non-empty EnvVars entry -> App.LoadConfig values -> Default.
+type Opt struct {
+	// Name is the long option name, eg. "token" for "--token".
+	Name string
+	// Desc is the option description, shown in help output.
+	Desc string
+	// Default is the fallback value used when nothing else resolves.
+	Default string
+	// DefaultText overrides how the default is displayed in help output,
+	// useful when Default itself isn't meaningful to show (eg. a
+	// resolved absolute path).
+	DefaultText string
+	// EnvVars are checked in order for a non-empty fallback value
+	// before Default is used.
+	EnvVars []string
+}
+
+// AddOpt registers opt on the command so Resolve can fall back to its
+// env vars / config value / default.
+func (c *Command) AddOpt(opt *Opt) *Opt {
+	if c.opts == nil {
+		c.opts = make(map[string]*Opt)
+	}
+
+	c.opts[opt.Name] = opt
+	return opt
+}
+
+// Opts returns the options registered on the command via AddOpt.
+func (c *Command) Opts() map[string]*Opt {
+	return c.opts
+}
+
+// optNames lists the registered option names, sorted for stable output.
+func (c *Command) optNames() []string {
+	names := make([]string, 0, len(c.opts))
+	for name := range c.opts {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// flagNames lists "--name" completion candidates for the command's
+// registered options.
+func (c *Command) flagNames() []string {
+	names := c.optNames()
+	flags := make([]string, len(names))
+	for i, name := range names {
+		flags[i] = "--" + name
+	}
+	return flags
+}
+
+// Resolve returns the effective value for opt given the parsed CLI
+// value (empty when the flag wasn't passed on the command line),
+// applying the CLI -> env -> config -> default precedence.
+func (c *Command) Resolve(opt *Opt, cliVal string) string {
+	if cliVal != "" {
+		return cliVal
+	}
+
+	for _, name := range opt.EnvVars {
+		if v := os.Getenv(name); v != "" {
+			return v
+		}
+	}
+
+	if c.app != nil {
+		if v, ok := c.app.configValues[opt.Name]; ok && v != "" {
+			return v
+		}
+	}
+
+	return opt.Default
+}
@@ -0,0 +1,39 @@
+package gcli
+
+import (
+	"os"
+	"testing"
+)
+
+func TestCommand_Resolve_precedence(t *testing.T) {
+	app := newTestApp()
+	c := app.AddCommand(NewCommand("deploy", "deploy the app", nil))
+
+	opt := c.AddOpt(&Opt{
+		Name:    "token",
+		EnvVars: []string{"APP_TOKEN"},
+		Default: "anon",
+	})
+
+	if got := c.Resolve(opt, "cli-value"); got != "cli-value" {
+		t.Errorf("explicit CLI value should win, got %q", got)
+	}
+
+	if got := c.Resolve(opt, ""); got != "anon" {
+		t.Errorf("expected default fallback %q, got %q", "anon", got)
+	}
+
+	os.Setenv("APP_TOKEN", "env-value")
+	defer os.Unsetenv("APP_TOKEN")
+
+	if got := c.Resolve(opt, ""); got != "env-value" {
+		t.Errorf("expected env fallback %q, got %q", "env-value", got)
+	}
+
+	app.configValues = map[string]string{"token": "config-value"}
+	os.Unsetenv("APP_TOKEN")
+
+	if got := c.Resolve(opt, ""); got != "config-value" {
+		t.Errorf("expected config fallback %q, got %q", "config-value", got)
+	}
+}
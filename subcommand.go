@@ -0,0 +1,129 @@
+package gcli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+/*************************************************************
+ * nested subcommands
+ *************************************************************/
+
+// AddSubCommand add a child command under c. The child is reachable as
+// `app c.Name sub.Name --flag`, in addition to (or instead of) the flat
+// `module:name` convention.
+func (c *Command) AddSubCommand(sub *Command) *Command {
+	sub.Name = strings.TrimSpace(sub.Name)
+	if sub.Name == "" {
+		exitWithErr("The added subcommand name can not be empty.")
+	}
+
+	if sub.IsDisabled() {
+		Logf(VerbDebug, "command %s has been disabled, skip add as subcommand of %s", sub.Name, c.Name)
+		return sub
+	}
+
+	if c.Subcommands == nil {
+		c.Subcommands = make(map[string]*Command)
+	}
+
+	sub.parent = c
+	c.Subcommands[sub.Name] = sub
+	sub.setApp(c.app)
+	sub.initialize()
+
+	Logf(VerbDebug, "[Command.AddSubCommand] add a new subcommand: %s under %s", sub.Name, c.Name)
+	return sub
+}
+
+// setApp sets app on c and recursively on every command already in its
+// Subcommands tree. Subcommands are often wired up before their parent
+// is registered with an App (cmd.AddSubCommand(sub); app.AddCommand(cmd)),
+// so propagation can't rely solely on AddSubCommand running after app is known.
+func (c *Command) setApp(app *App) {
+	c.app = app
+	for _, sub := range c.Subcommands {
+		sub.setApp(app)
+	}
+}
+
+// resolve walks args token-by-token down the Subcommands tree, returning
+// the deepest matching command and the remaining, unconsumed args.
+func (c *Command) resolve(args []string) (*Command, []string) {
+	if len(args) == 0 || len(c.Subcommands) == 0 {
+		return c, args
+	}
+
+	sub, ok := c.Subcommands[args[0]]
+	if !ok {
+		return c, args
+	}
+
+	return sub.resolve(args[1:])
+}
+
+// Dispatch runs the command chain for args, firing EvtBefore/EvtAfter
+// (and EvtError on failure) at every level of the chain - from the
+// top-level command down to the leaf that actually handles args.
+func (c *Command) Dispatch(args []string) error {
+	// resolve the chain first: a --help further down args belongs to a
+	// deeper subcommand, not this level, eg. `my-cmd sub --help` must
+	// show sub's help, not my-cmd's.
+	sub, ok := c.Subcommands[firstOr(args, "")]
+	if ok {
+		c.Fire(EvtBefore, c, args)
+		err := sub.Dispatch(args[1:])
+		c.Fire(EvtAfter, c, args)
+
+		if err != nil {
+			c.Fire(EvtError, c, err)
+		}
+		return err
+	}
+
+	if hasHelpFlag(args) {
+		c.Help(os.Stdout)
+		return nil
+	}
+	return c.run(args)
+}
+
+// run executes this command's own Fn, firing its before/after/error hooks.
+func (c *Command) run(args []string) error {
+	c.Fire(EvtBefore, c, args)
+
+	var err error
+	if c.Fn != nil {
+		err = c.Fn(c, args)
+	}
+
+	c.Fire(EvtAfter, c, args)
+	if err != nil {
+		c.Fire(EvtError, c, err)
+	}
+	return err
+}
+
+// firstOr returns args[0], or def when args is empty.
+func firstOr(args []string, def string) string {
+	if len(args) == 0 {
+		return def
+	}
+	return args[0]
+}
+
+// Dispatch resolves name (a top-level command or alias) plus the
+// remaining args down the Subcommands tree and runs the matched chain.
+func (app *App) Dispatch(name string, args []string) error {
+	if real, ok := app.aliases[name]; ok {
+		name = real
+	}
+
+	c, ok := app.commands[name]
+	if !ok {
+		return fmt.Errorf("unknown command: %s", name)
+	}
+
+	return c.Dispatch(args)
+}
@@ -0,0 +1,64 @@
+package gcli
+
+import (
+	"testing"
+)
+
+func TestCommand_AddSubCommand_dispatch(t *testing.T) {
+	app := newTestApp()
+
+	var ranWith []string
+	sub := NewCommand("sub", "a sub command", func(c *Command) {
+		c.Fn = func(c *Command, args []string) error {
+			ranWith = args
+			return nil
+		}
+	})
+
+	top := app.AddCommand(NewCommand("my-cmd", "a top command", nil))
+	top.AddSubCommand(sub)
+
+	var order []string
+	top.On(EvtBefore, func(args ...interface{}) { order = append(order, "before") })
+	top.On(EvtAfter, func(args ...interface{}) { order = append(order, "after") })
+
+	if err := app.Dispatch("my-cmd", []string{"sub", "--flag"}); err != nil {
+		t.Fatalf("Dispatch error: %v", err)
+	}
+
+	if len(ranWith) != 1 || ranWith[0] != "--flag" {
+		t.Errorf("subcommand did not receive the remaining args, got %v", ranWith)
+	}
+	if len(order) != 2 || order[0] != "before" || order[1] != "after" {
+		t.Errorf("parent hooks did not fire around the subcommand dispatch, got %v", order)
+	}
+}
+
+func TestCommand_AddSubCommand_appBeforeRegistration(t *testing.T) {
+	app := newTestApp()
+
+	// build the subtree before the parent is registered with the app -
+	// AddSubCommand can't rely on c.app being set yet in this order.
+	top := NewCommand("my-cmd", "a top command", nil)
+	sub := top.AddSubCommand(NewCommand("sub", "a sub command", nil))
+	app.AddCommand(top)
+
+	if sub.app != app {
+		t.Error("subcommand's app was not backfilled once the parent was registered")
+	}
+}
+
+func TestCommand_resolve(t *testing.T) {
+	app := newTestApp()
+
+	top := app.AddCommand(NewCommand("my-cmd", "a top command", nil))
+	top.AddSubCommand(NewCommand("sub", "a sub command", nil))
+
+	resolved, rest := top.resolve([]string{"sub", "extra"})
+	if resolved.Name != "sub" {
+		t.Errorf("expected to resolve into %q, got %q", "sub", resolved.Name)
+	}
+	if len(rest) != 1 || rest[0] != "extra" {
+		t.Errorf("expected remaining args [extra], got %v", rest)
+	}
+}